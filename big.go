@@ -0,0 +1,190 @@
+package frac
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Shortcut for `ParseBigWith` with `ParseOpts{}`, which rejects
+// over-precision input, same as `Parse`.
+func ParseBig(src string, frac uint, radix uint) (*big.Int, error) {
+	return ParseBigWith(src, frac, radix, ParseOpts{})
+}
+
+/*
+Same as `ParseWith`, but returns a `*big.Int` instead of `int64`, for values
+beyond what fits in 64 bits: large financial ledgers, cryptographic
+amounts, and the like. Supports the same `RoundingMode` options via
+`ParseOpts`. Unlike `ParseWith`, does not accept an exponent suffix: an
+"e"/"p" marker is rejected as a non-digit character.
+*/
+func ParseBigWith(src string, frac uint, radix uint, opts ParseOpts) (*big.Int, error) {
+	if len(src) == 0 {
+		return nil, fmt.Errorf(`can't parse empty input as number`)
+	}
+
+	if !(radix >= 2 && radix <= 36) {
+		return nil, fmt.Errorf(`can't parse %q as number: unsupported radix %v`, src, radix)
+	}
+
+	var neg bool
+	var fracDigs uint
+	var excessSeen bool
+	var excessFirst byte
+	var excessRest bool
+
+	const (
+		stepSign = iota
+		stepMantStart
+		stepMant
+		stepFracStart
+		stepFrac
+	)
+	step := stepSign
+
+	num := new(big.Int)
+	rad := big.NewInt(int64(radix))
+
+	for i, char := range []byte(src) {
+		if step == stepSign {
+			if char == '+' {
+				step = stepMantStart
+				continue
+			}
+
+			if char == '-' {
+				neg = true
+				step = stepMantStart
+				continue
+			}
+
+			step = stepMantStart
+		}
+
+		if step == stepMant && char == '.' {
+			step = stepFracStart
+			continue
+		}
+
+		if step == stepMantStart {
+			step = stepMant
+		} else if step == stepFracStart {
+			step = stepFrac
+		}
+
+		digit := toDigit(char)
+		if digit == unDigit || uint(digit) >= radix {
+			return nil, fmt.Errorf(`can't parse %q as number (radix %v, fraction %v): found non-digit character %q`,
+				src, radix, frac, runeAt(src, i))
+		}
+
+		if step == stepFrac {
+			fracDigs++
+			if fracDigs > frac {
+				if !excessSeen {
+					excessSeen = true
+					excessFirst = digit
+				} else if digit != 0 {
+					excessRest = true
+				}
+				if digit != 0 && opts.Rounding == RoundStrict {
+					return nil, fmt.Errorf(`can't parse %q as number (radix %v, fraction %v): too many fractional digits`,
+						src, radix, frac)
+				}
+				continue
+			}
+		}
+
+		num.Mul(num, rad)
+		num.Add(num, big.NewInt(int64(digit)))
+	}
+
+	for ; fracDigs < frac; fracDigs++ {
+		num.Mul(num, rad)
+	}
+
+	if excessSeen {
+		away, err := roundAwayBig(opts.Rounding, num, radix, excessFirst, excessRest, neg)
+		if err != nil {
+			return nil, fmt.Errorf(`can't parse %q as number: %w`, src, err)
+		}
+		if away {
+			num.Add(num, big.NewInt(1))
+		}
+	}
+
+	if step != stepMant && step != stepFrac {
+		return nil, fmt.Errorf(`can't parse %q as number (radix %v, fraction %v): unexpected end of input`,
+			src, radix, frac)
+	}
+
+	if neg {
+		num.Neg(num)
+	}
+	return num, nil
+}
+
+// Shortcut for `AppendBig(nil, num, frac, radix)`, returned as a string.
+func FormatBig(num *big.Int, frac uint, radix uint) (string, error) {
+	buf, err := AppendBig(nil, num, frac, radix)
+	return bytesToMutableString(buf), err
+}
+
+// Same as `Append` but takes a `*big.Int`.
+func AppendBig(buf []byte, num *big.Int, frac uint, radix uint) ([]byte, error) {
+	if !(radix >= 2 && radix <= 36) {
+		return buf, fmt.Errorf(`can't encode %v: unsupported radix %v`, num, radix)
+	}
+
+	neg := num.Sign() < 0
+	unum := new(big.Int).Abs(num)
+	rad := big.NewInt(int64(radix))
+	rem := new(big.Int)
+
+	// Built in least-significant-digit-first order, then reversed once at
+	// the end, the same order `Append` fills its stack buffer from the
+	// right.
+	var out []byte
+
+	trailing := true
+	for f := frac; f > 0; f-- {
+		unum.QuoRem(unum, rad, rem)
+		digit := rem.Uint64()
+
+		if digit == 0 && trailing {
+			continue
+		}
+		trailing = false
+
+		out = append(out, digits[digit])
+		if f == 1 {
+			out = append(out, '.')
+		}
+	}
+
+	for unum.Cmp(rad) >= 0 {
+		unum.QuoRem(unum, rad, rem)
+		out = append(out, digits[rem.Uint64()])
+	}
+	out = append(out, digits[unum.Uint64()])
+
+	if neg {
+		out = append(out, '-')
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return append(buf, out...), nil
+}
+
+// Same as `roundAway` but for a non-negative `*big.Int` magnitude, with the
+// sign passed in separately since the big-integer parser accumulates an
+// unsigned magnitude until the very end. Shares its decision logic with
+// `roundAway` via `decideRoundAway`.
+func roundAwayBig(mode RoundingMode, num *big.Int, radix uint, first byte, rest bool, neg bool) (bool, error) {
+	return decideRoundAway(mode, radix, first, rest, neg, func() bool {
+		mod := new(big.Int).Mod(num, big.NewInt(int64(radix)))
+		return mod.Bit(0) == 1
+	})
+}
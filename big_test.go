@@ -0,0 +1,30 @@
+package frac
+
+import "testing"
+
+// Regression test for the shared `decideRoundAway` core: `roundAwayBig`
+// must agree with `roundAway` mode-for-mode instead of drifting as its own
+// copy of the switch statement.
+func TestParseBigWithRoundingMatchesParseWith(t *testing.T) {
+	modes := []RoundingMode{
+		RoundDown, RoundUp, RoundHalfEven, RoundHalfUp, RoundHalfDown,
+		RoundCeiling, RoundFloor,
+	}
+
+	for _, mode := range modes {
+		for _, src := range []string{"1.005", "-1.005", "1.015", "1.004"} {
+			want, wantErr := ParseWith(src, 2, 10, ParseOpts{Rounding: mode})
+
+			got, err := ParseBigWith(src, 2, 10, ParseOpts{Rounding: mode})
+			if (err != nil) != (wantErr != nil) {
+				t.Fatalf(`mode %v, %q: error mismatch: int64 %v, big %v`, mode, src, wantErr, err)
+			}
+			if err != nil {
+				continue
+			}
+			if got.Int64() != want {
+				t.Fatalf(`mode %v, %q: expected %v, got %v`, mode, src, want, got)
+			}
+		}
+	}
+}
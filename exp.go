@@ -0,0 +1,284 @@
+package frac
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Upper bound on the accumulated exponent value, chosen generously above
+// anything that could avoid overflowing `int64` once applied, while staying
+// far below the range where `expVal*10` itself could overflow `uint64`.
+const maxExpVal = 1_000_000
+
+// True if `char` is the exponent marker for the given radix: "e"/"E" for
+// decimal, "p"/"P" otherwise (mirroring Go's hex floats). A marker that
+// would also be a valid digit in the given radix (e.g. "p" in radix 26 or
+// above) is treated as a digit instead, never as a marker.
+func isExpMarker(char byte, radix uint) bool {
+	lc := lower(char)
+	return lc == expMarker(radix) && uint(toDigit(lc)) >= radix
+}
+
+// The exponent marker character for the given radix: "e" for decimal, "p"
+// otherwise (mirroring Go's hex floats).
+func expMarker(radix uint) byte {
+	if radix == 10 {
+		return 'e'
+	}
+	return 'p'
+}
+
+// True if the exponent marker for the given radix is also a valid digit in
+// that radix (e.g. "p" in radix 36), making it ambiguous to use as a
+// marker. `isExpMarker` already resolves this ambiguity in favor of
+// treating the character as a digit; `appendScientific` must avoid
+// producing such a character as a marker in the first place.
+func expMarkerAmbiguous(radix uint) bool {
+	return uint(toDigit(expMarker(radix))) < radix
+}
+
+/*
+Splits `src` into its mantissa and an optional trailing exponent, parsing
+the exponent if present. Returns the whole of `src` as the mantissa, with
+`hasExp` false, if no exponent marker is found.
+*/
+func splitExponent(src string, radix uint) (mantissa string, expNeg bool, expVal uint64, hasExp bool, err error) {
+	for i := 0; i < len(src); i++ {
+		if isExpMarker(src[i], radix) {
+			expNeg, expVal, err = parseExpPart(src, src[i+1:])
+			if err != nil {
+				return "", false, 0, false, err
+			}
+			return src[:i], expNeg, expVal, true, nil
+		}
+	}
+	return src, false, 0, false, nil
+}
+
+// Parses the exponent suffix following the marker: an optional sign,
+// followed by one or more decimal digits.
+func parseExpPart(src string, part string) (neg bool, val uint64, err error) {
+	if len(part) == 0 {
+		return false, 0, fmt.Errorf(`can't parse %q as number: missing exponent digits`, src)
+	}
+
+	i := 0
+	switch part[0] {
+	case '+':
+		i = 1
+	case '-':
+		neg = true
+		i = 1
+	}
+	if i >= len(part) {
+		return false, 0, fmt.Errorf(`can't parse %q as number: missing exponent digits`, src)
+	}
+
+	for ; i < len(part); i++ {
+		char := part[i]
+		if char < '0' || char > '9' {
+			return false, 0, fmt.Errorf(`can't parse %q as number: found non-digit character %q in exponent`,
+				src, rune(char))
+		}
+
+		val = val*10 + uint64(char-'0')
+		if val > maxExpVal {
+			return false, 0, fmt.Errorf(`can't parse %q as number: exponent too large`, src)
+		}
+	}
+	return neg, val, nil
+}
+
+/*
+Options for `FormatWith` and `AppendWith`.
+
+When `Scientific` is false, the zero value, formatting is identical to
+`Format`. When `Scientific` is true, the value is written in scientific
+notation, normalized to a single leading significant digit, whenever its
+base-`radix` exponent falls outside `[MinExp, MaxExp]`; otherwise it's
+written the same way `Format` would. Zero is always written as plain "0",
+without an exponent.
+
+For radixes above 25, the exponent marker "p" is also a valid digit, which
+would make scientific notation ambiguous to parse back; in that case
+`Scientific` is ignored and formatting falls back to `Format`.
+*/
+type FormatOpts struct {
+	Scientific     bool
+	MinExp, MaxExp int
+}
+
+// Shortcut for `AppendWith(nil, num, frac, radix, opts)`, returned as a string.
+func FormatWith(num int64, frac uint, radix uint, opts FormatOpts) (string, error) {
+	buf, err := AppendWith(nil, num, frac, radix, opts)
+	return bytesToMutableString(buf), err
+}
+
+/*
+Same as `Append`, but takes `FormatOpts` to optionally request scientific
+notation for values outside the given exponent range. See `FormatOpts`.
+*/
+func AppendWith(buf []byte, num int64, frac uint, radix uint, opts FormatOpts) ([]byte, error) {
+	if !(radix >= 2 && radix <= 36) {
+		return buf, fmt.Errorf(`can't encode %v: unsupported radix %v`, num, radix)
+	}
+
+	if !opts.Scientific || expMarkerAmbiguous(radix) {
+		return Append(buf, num, frac, radix)
+	}
+
+	exp := magExp(num, radix, frac)
+	if exp >= opts.MinExp && exp <= opts.MaxExp {
+		return Append(buf, num, frac, radix)
+	}
+	return appendScientific(buf, num, radix, exp), nil
+}
+
+// Base-`radix` exponent of `num / radix^frac`, normalized so the mantissa
+// has exactly one digit before the point. Zero for a zero `num`.
+func magExp(num int64, radix uint, frac uint) int {
+	if num == 0 {
+		return 0
+	}
+
+	var mag uint64
+	if num < 0 {
+		mag = uint64(-num)
+	} else {
+		mag = uint64(num)
+	}
+
+	rad := uint64(radix)
+	digs := 0
+	for mag > 0 {
+		mag /= rad
+		digs++
+	}
+	return digs - 1 - int(frac)
+}
+
+// Renders `num` in normalized scientific notation: a single leading digit,
+// an optional fraction with trailing zeros trimmed, and a signed exponent
+// suffix.
+func appendScientific(buf []byte, num int64, radix uint, exp int) []byte {
+	var neg bool
+	var unum uint64
+	if num < 0 {
+		neg = true
+		unum = uint64(-num)
+	} else {
+		unum = uint64(num)
+	}
+
+	if unum == 0 {
+		return append(buf, '0')
+	}
+
+	// Up to all bits of the number.
+	var local [unsafe.Sizeof(num) * 8]byte
+	i := len(local)
+	rad := uint64(radix)
+	var digit uint64
+
+	for unum >= rad {
+		unum, digit = pop(unum, rad)
+		i--
+		local[i] = digits[digit]
+	}
+	i--
+	local[i] = digits[unum]
+	mantissa := local[i:]
+
+	if neg {
+		buf = append(buf, '-')
+	}
+	buf = append(buf, mantissa[0])
+
+	rest := mantissa[1:]
+	for len(rest) > 0 && rest[len(rest)-1] == '0' {
+		rest = rest[:len(rest)-1]
+	}
+	if len(rest) > 0 {
+		buf = append(buf, '.')
+		buf = append(buf, rest...)
+	}
+
+	buf = append(buf, expMarker(radix))
+
+	if exp < 0 {
+		buf = append(buf, '-')
+		exp = -exp
+	} else {
+		buf = append(buf, '+')
+	}
+	return appendUint(buf, exp)
+}
+
+// Appends the decimal digits of a non-negative `val`.
+func appendUint(buf []byte, val int) []byte {
+	if val == 0 {
+		return append(buf, '0')
+	}
+
+	var local [20]byte
+	i := len(local)
+	for val > 0 {
+		i--
+		local[i] = byte('0') + byte(val%10)
+		val /= 10
+	}
+	return append(buf, local[i:]...)
+}
+
+/*
+Folds a parsed exponent into `num`, which is already shifted by `frac`
+digits. A non-negative exponent multiplies `num` by `radix^exp`. A negative
+exponent divides it by `radix^exp`, shedding digits past `frac` the same way
+over-precision input does, subject to `mode`.
+*/
+func applyExp(src string, num int64, radix uint, sign int64, mode RoundingMode, neg bool, exp uint64) (int64, error) {
+	if !neg {
+		var err error
+		for ; exp > 0; exp-- {
+			num, err = inc(src, num, radix, 1, 0)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return num, nil
+	}
+
+	rad := int64(radix)
+	var first byte
+	var rest bool
+
+	for i := uint64(0); i < exp; i++ {
+		if i > 0 && first != 0 {
+			rest = true
+		}
+
+		rem := num % rad
+		if rem < 0 {
+			rem = -rem
+		}
+		num /= rad
+		first = byte(rem)
+
+		if first != 0 && mode == RoundStrict {
+			return 0, fmt.Errorf(`can't parse %q as number (radix %v): too many fractional digits`, src, radix)
+		}
+	}
+
+	if exp == 0 {
+		return num, nil
+	}
+
+	away, err := roundAway(src, mode, num, radix, first, rest, sign < 0)
+	if err != nil {
+		return 0, err
+	}
+	if away {
+		return bump(src, num, sign)
+	}
+	return num, nil
+}
@@ -0,0 +1,80 @@
+package frac
+
+import "testing"
+
+func TestParseWithExponent(t *testing.T) {
+	t.Run(`positive exponent restores significance to excess digits`, func(t *testing.T) {
+		num, err := Parse(`1.2345e2`, 2, 10)
+		if err != nil {
+			t.Fatalf(`unexpected error: %v`, err)
+		}
+		if num != 12345 {
+			t.Fatalf(`expected 12345, got %v`, num)
+		}
+	})
+
+	t.Run(`positive exponent with fewer literal digits still shifts`, func(t *testing.T) {
+		num, err := Parse(`1.5e1`, 0, 10)
+		if err != nil {
+			t.Fatalf(`unexpected error: %v`, err)
+		}
+		if num != 15 {
+			t.Fatalf(`expected 15, got %v`, num)
+		}
+
+		num, err = Parse(`5e3`, 0, 10)
+		if err != nil {
+			t.Fatalf(`unexpected error: %v`, err)
+		}
+		if num != 5000 {
+			t.Fatalf(`expected 5000, got %v`, num)
+		}
+	})
+
+	t.Run(`negative exponent still rejects genuine over-precision`, func(t *testing.T) {
+		if _, err := Parse(`1.23e-1`, 2, 10); err == nil {
+			t.Fatalf(`expected error, got none`)
+		}
+
+		num, err := ParseWith(`1.23e-1`, 2, 10, ParseOpts{Rounding: RoundDown})
+		if err != nil {
+			t.Fatalf(`unexpected error: %v`, err)
+		}
+		if num != 12 {
+			t.Fatalf(`expected 12, got %v`, num)
+		}
+	})
+
+	t.Run(`literal over-precision without an exponent is unaffected`, func(t *testing.T) {
+		if _, err := Parse(`123.456`, 2, 10); err == nil {
+			t.Fatalf(`expected error, got none`)
+		}
+	})
+}
+
+func TestAppendWithScientificAmbiguousMarker(t *testing.T) {
+	// Radix 30: "p" is a valid digit (value 25), so scientific notation
+	// would be unparsable; `AppendWith` must fall back to `Append`.
+	const num, frac, radix = 123456789, 2, 30
+
+	text, err := FormatWith(num, frac, radix, FormatOpts{Scientific: true, MaxExp: -1})
+	if err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+
+	plain, err := Format(num, frac, radix)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+	if text != plain {
+		t.Fatalf(`expected scientific formatting to fall back to %q, got %q`, plain, text)
+	}
+
+	back, err := Parse(text, frac, radix)
+	if err != nil {
+		t.Fatalf(`round-trip parse failed: %v`, err)
+	}
+	if back != num {
+		t.Fatalf(`expected %v, got %v`, num, back)
+	}
+}
@@ -0,0 +1,113 @@
+package frac
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+)
+
+/*
+Bundles a fixed-point value with its precision and radix, so it can be
+used directly as a struct field or database column instead of a leaf
+`int64`. Implements `encoding.TextMarshaler`/`TextUnmarshaler`,
+`json.Marshaler`/`Unmarshaler`, `sql.Scanner`/`driver.Valuer`, and
+`fmt.Stringer`, all on top of `Parse`/`Format`.
+
+`Frac` and `Radix` must be set before unmarshaling (from JSON, text, or a
+database row), since there's nothing in the wire format to infer them
+from. This does not implement `fmt.Scanner`: that interface's `Scan`
+method has the same name as `sql.Scanner`'s but a different signature, so
+a single type can satisfy only one of them, and `sql.Scanner` is the more
+broadly useful of the two here.
+*/
+type Fixed struct {
+	Val         int64
+	Frac, Radix uint
+
+	// When true, `MarshalJSON` emits `Val` as a quoted string instead of a
+	// bare number, for JSON consumers (notably JavaScript) that can't
+	// safely round-trip large integers through a number token.
+	AsString bool
+}
+
+// Implements `fmt.Stringer`.
+func (f Fixed) String() string {
+	return tryString(Format(f.Val, f.Frac, f.Radix))
+}
+
+// Implements `encoding.TextMarshaler`.
+func (f Fixed) MarshalText() ([]byte, error) {
+	return Append(nil, f.Val, f.Frac, f.Radix)
+}
+
+// Implements `encoding.TextUnmarshaler`. `f.Frac` and `f.Radix` must
+// already be set.
+func (f *Fixed) UnmarshalText(text []byte) error {
+	val, err := Unmarshal(text, f.Frac, f.Radix)
+	if err != nil {
+		return err
+	}
+	f.Val = val
+	return nil
+}
+
+// Implements `json.Marshaler`. Emits an unquoted number token, unless
+// `f.AsString` requests a quoted one, or `f.Radix` isn't 10, in which case
+// the text isn't valid JSON number syntax and must be quoted regardless.
+func (f Fixed) MarshalJSON() ([]byte, error) {
+	text, err := Append(nil, f.Val, f.Frac, f.Radix)
+	if err != nil {
+		return nil, err
+	}
+	if !f.AsString && f.Radix == 10 {
+		return text, nil
+	}
+
+	out := make([]byte, 0, len(text)+2)
+	out = append(out, '"')
+	out = append(out, text...)
+	out = append(out, '"')
+	return out, nil
+}
+
+// Implements `json.Unmarshaler`, accepting both a bare number token and a
+// quoted string (as produced by `MarshalJSON` with `AsString` or a
+// non-decimal `Radix`). `f.Frac` and `f.Radix` must already be set. A
+// literal `null` is a no-op, per the `json.Unmarshaler` convention.
+func (f *Fixed) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	return f.UnmarshalText(data)
+}
+
+// Implements `sql.Scanner`. `f.Frac` and `f.Radix` must already be set.
+func (f *Fixed) Scan(src any) error {
+	switch val := src.(type) {
+	case nil:
+		f.Val = 0
+		return nil
+	case int64:
+		f.Val = val
+		return nil
+	case []byte:
+		return f.UnmarshalText(val)
+	case string:
+		return f.UnmarshalText([]byte(val))
+	default:
+		return fmt.Errorf(`can't scan %T into Fixed`, src)
+	}
+}
+
+// Implements `driver.Valuer`.
+func (f Fixed) Value() (driver.Value, error) {
+	text, err := Append(nil, f.Val, f.Frac, f.Radix)
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
@@ -0,0 +1,37 @@
+package frac
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFixedMarshalJSONNonDecimalRadix(t *testing.T) {
+	f := Fixed{Val: 0xAB, Frac: 0, Radix: 16}
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+	if string(data) != `"ab"` {
+		t.Fatalf(`expected %q, got %q`, `"ab"`, data)
+	}
+
+	var out Fixed
+	out.Frac, out.Radix = 0, 16
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+	if out.Val != 0xAB {
+		t.Fatalf(`expected %v, got %v`, 0xAB, out.Val)
+	}
+}
+
+func TestFixedUnmarshalJSONNull(t *testing.T) {
+	out := Fixed{Val: 123, Frac: 2, Radix: 10}
+	if err := json.Unmarshal([]byte(`null`), &out); err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+	if out.Val != 123 {
+		t.Fatalf(`expected null to be a no-op, got %v`, out.Val)
+	}
+}
@@ -0,0 +1,270 @@
+package frac
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+Locale-aware formatting and parsing on top of `Append`/`ParseWith`: custom
+decimal and group separators, CLDR-style group sizes (for example `[3, 2]`
+for Indian lakh grouping: "1,23,456"), minimum digit counts, a pad
+character for the integer part, and affixes for sign and unit.
+
+The zero value matches `Append`/`Parse`: decimal point, no grouping, a
+single leading "-" for negative numbers, and no affixes.
+*/
+type Formatter struct {
+	DecimalSep rune
+	GroupSep   rune
+	GroupSizes []int
+
+	MinIntDigits  int
+	MinFracDigits int
+	PadChar       rune
+
+	PositivePrefix string
+	NegativePrefix string
+	Suffix         string
+}
+
+// Same as `Append` but renders the result according to `Formatter`.
+func (f Formatter) Append(buf []byte, num int64, frac uint, radix uint) ([]byte, error) {
+	if !(radix >= 2 && radix <= 36) {
+		return buf, fmt.Errorf(`can't encode %v: unsupported radix %v`, num, radix)
+	}
+
+	decSep, _, negPrefix := f.defaults()
+
+	var neg bool
+	var unum uint64
+	if num < 0 {
+		neg = true
+		unum = uint64(-num)
+	} else {
+		unum = uint64(num)
+	}
+	rad := uint64(radix)
+
+	fracDigits := make([]rune, frac)
+	for i := int(frac) - 1; i >= 0; i-- {
+		var digit uint64
+		unum, digit = pop(unum, rad)
+		fracDigits[i] = rune(digits[digit])
+	}
+	for len(fracDigits) > f.MinFracDigits && len(fracDigits) > 0 && fracDigits[len(fracDigits)-1] == '0' {
+		fracDigits = fracDigits[:len(fracDigits)-1]
+	}
+	for len(fracDigits) < f.MinFracDigits {
+		fracDigits = append(fracDigits, '0')
+	}
+
+	var intDigits []rune
+	for unum > 0 {
+		var digit uint64
+		unum, digit = pop(unum, rad)
+		intDigits = append(intDigits, rune(digits[digit]))
+	}
+	for i, j := 0, len(intDigits)-1; i < j; i, j = i+1, j-1 {
+		intDigits[i], intDigits[j] = intDigits[j], intDigits[i]
+	}
+	if len(intDigits) == 0 {
+		intDigits = []rune{'0'}
+	}
+
+	pad := f.PadChar
+	if pad == 0 {
+		pad = '0'
+	}
+	for len(intDigits) < f.MinIntDigits {
+		intDigits = append([]rune{pad}, intDigits...)
+	}
+	intDigits = groupDigits(intDigits, f.GroupSep, f.GroupSizes)
+
+	var out []rune
+	if neg {
+		out = append(out, []rune(negPrefix)...)
+	} else {
+		out = append(out, []rune(f.PositivePrefix)...)
+	}
+	out = append(out, intDigits...)
+	if len(fracDigits) > 0 {
+		out = append(out, decSep)
+		out = append(out, fracDigits...)
+	}
+	out = append(out, []rune(f.Suffix)...)
+
+	var tmp [utf8.UTFMax]byte
+	for _, r := range out {
+		n := utf8.EncodeRune(tmp[:], r)
+		buf = append(buf, tmp[:n]...)
+	}
+	return buf, nil
+}
+
+/*
+Parses text previously produced by `Append` with the same `Formatter`.
+Group separators are optional but, when present, must fall exactly on the
+boundaries implied by `GroupSizes`; anything else is a parse error.
+`PadChar` is not stripped, so text padded with a non-digit `PadChar` fails
+to parse.
+*/
+func (f Formatter) Parse(src string, frac uint, radix uint) (int64, error) {
+	if !(radix >= 2 && radix <= 36) {
+		return 0, fmt.Errorf(`can't parse %q as number: unsupported radix %v`, src, radix)
+	}
+
+	decSep, posPrefix, negPrefix := f.defaults()
+
+	rest := src
+	if f.Suffix != "" {
+		if !strings.HasSuffix(rest, f.Suffix) {
+			return 0, fmt.Errorf(`can't parse %q as number: missing suffix %q`, src, f.Suffix)
+		}
+		rest = rest[:len(rest)-len(f.Suffix)]
+	}
+
+	sign := ""
+	switch {
+	case negPrefix != "" && strings.HasPrefix(rest, negPrefix):
+		sign = "-"
+		rest = rest[len(negPrefix):]
+	case posPrefix != "" && strings.HasPrefix(rest, posPrefix):
+		rest = rest[len(posPrefix):]
+	}
+
+	intPart, fracPart := rest, ""
+	if idx := strings.IndexRune(rest, decSep); idx >= 0 {
+		intPart, fracPart = rest[:idx], rest[idx+utf8.RuneLen(decSep):]
+	}
+
+	cleanInt, err := f.ungroupInt(intPart)
+	if err != nil {
+		return 0, fmt.Errorf(`can't parse %q as number: %w`, src, err)
+	}
+
+	canonical := sign + cleanInt
+	if fracPart != "" {
+		canonical += "." + fracPart
+	}
+	return ParseWith(canonical, frac, radix, ParseOpts{})
+}
+
+// Fills in the defaults implied by the zero value of `Formatter`.
+func (f Formatter) defaults() (decSep rune, posPrefix, negPrefix string) {
+	decSep = f.DecimalSep
+	if decSep == 0 {
+		decSep = '.'
+	}
+	posPrefix, negPrefix = f.PositivePrefix, f.NegativePrefix
+	if f.NegativePrefix == "" {
+		negPrefix = "-"
+	}
+	return
+}
+
+// Strips and validates group separators out of the integer part, returning
+// the plain digit string.
+func (f Formatter) ungroupInt(intPart string) (string, error) {
+	sep := f.GroupSep
+	runes := []rune(intPart)
+
+	if sep == 0 {
+		return intPart, nil
+	}
+
+	digitCount := 0
+	for _, r := range runes {
+		if r != sep {
+			digitCount++
+		}
+	}
+	bounds := groupBoundaries(digitCount, f.GroupSizes)
+
+	clean := make([]rune, 0, len(runes))
+	seen := 0
+	for i := len(runes) - 1; i >= 0; i-- {
+		r := runes[i]
+		if r == sep {
+			if !bounds[seen] {
+				return "", fmt.Errorf(`misplaced group separator in %q`, intPart)
+			}
+			continue
+		}
+		clean = append(clean, r)
+		seen++
+	}
+	for i, j := 0, len(clean)-1; i < j; i, j = i+1, j-1 {
+		clean[i], clean[j] = clean[j], clean[i]
+	}
+	return string(clean), nil
+}
+
+// Inserts `sep` into `digits` (most-significant first) at the boundaries
+// implied by `sizes`, CLDR-style: `sizes[0]` is the rightmost group, later
+// sizes apply moving left, and the last size repeats indefinitely.
+func groupDigits(digits []rune, sep rune, sizes []int) []rune {
+	if sep == 0 || len(sizes) == 0 {
+		return digits
+	}
+
+	var groups [][]rune
+	pos := len(digits)
+	idx := 0
+	for pos > 0 {
+		size := sizes[idx]
+		if idx < len(sizes)-1 {
+			idx++
+		}
+		if size <= 0 {
+			groups = append(groups, digits[:pos])
+			break
+		}
+
+		start := pos - size
+		if start < 0 {
+			start = 0
+		}
+		groups = append(groups, digits[start:pos])
+		pos = start
+	}
+
+	var out []rune
+	for i := len(groups) - 1; i >= 0; i-- {
+		if len(out) > 0 {
+			out = append(out, sep)
+		}
+		out = append(out, groups[i]...)
+	}
+	return out
+}
+
+// Set of digit counts (measured from the right) at which `groupDigits`
+// would place a separator, for a value with `n` integer digits.
+func groupBoundaries(n int, sizes []int) map[int]bool {
+	bounds := map[int]bool{}
+	if len(sizes) == 0 {
+		return bounds
+	}
+
+	pos := n
+	idx := 0
+	for pos > 0 {
+		size := sizes[idx]
+		if idx < len(sizes)-1 {
+			idx++
+		}
+		if size <= 0 {
+			break
+		}
+
+		start := pos - size
+		if start <= 0 {
+			break
+		}
+		bounds[n-start] = true
+		pos = start
+	}
+	return bounds
+}
@@ -0,0 +1,118 @@
+package frac
+
+import "testing"
+
+func TestFormatterNegativePrefixDefault(t *testing.T) {
+	// Setting only `PositivePrefix` must not drop the implicit "-" default
+	// for negative numbers.
+	f := Formatter{PositivePrefix: "+"}
+
+	buf, err := f.Append(nil, -12345, 2, 10)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+	if string(buf) != "-123.45" {
+		t.Fatalf(`expected "-123.45", got %q`, buf)
+	}
+
+	buf, err = f.Append(nil, 12345, 2, 10)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+	if string(buf) != "+123.45" {
+		t.Fatalf(`expected "+123.45", got %q`, buf)
+	}
+
+	num, err := f.Parse("-123.45", 2, 10)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+	if num != -12345 {
+		t.Fatalf(`expected -12345, got %v`, num)
+	}
+}
+
+func TestFormatterGrouping(t *testing.T) {
+	t.Run(`uniform group size`, func(t *testing.T) {
+		f := Formatter{GroupSep: ',', GroupSizes: []int{3}}
+
+		buf, err := f.Append(nil, 123456789, 0, 10)
+		if err != nil {
+			t.Fatalf(`unexpected error: %v`, err)
+		}
+		if string(buf) != "123,456,789" {
+			t.Fatalf(`expected "123,456,789", got %q`, buf)
+		}
+
+		num, err := f.Parse("123,456,789", 0, 10)
+		if err != nil {
+			t.Fatalf(`unexpected error: %v`, err)
+		}
+		if num != 123456789 {
+			t.Fatalf(`expected 123456789, got %v`, num)
+		}
+	})
+
+	t.Run(`CLDR-style Indian lakh grouping`, func(t *testing.T) {
+		f := Formatter{GroupSep: ',', GroupSizes: []int{3, 2}}
+
+		buf, err := f.Append(nil, 123456, 0, 10)
+		if err != nil {
+			t.Fatalf(`unexpected error: %v`, err)
+		}
+		if string(buf) != "1,23,456" {
+			t.Fatalf(`expected "1,23,456", got %q`, buf)
+		}
+
+		num, err := f.Parse("1,23,456", 0, 10)
+		if err != nil {
+			t.Fatalf(`unexpected error: %v`, err)
+		}
+		if num != 123456 {
+			t.Fatalf(`expected 123456, got %v`, num)
+		}
+	})
+
+	t.Run(`misplaced group separator is rejected`, func(t *testing.T) {
+		f := Formatter{GroupSep: ',', GroupSizes: []int{3}}
+
+		if _, err := f.Parse("12,3456", 0, 10); err == nil {
+			t.Fatalf(`expected an error for a misplaced group separator`)
+		}
+		if _, err := f.Parse("123,456", 0, 10); err != nil {
+			t.Fatalf(`unexpected error: %v`, err)
+		}
+	})
+}
+
+func TestFormatterMinIntDigitsAndPadChar(t *testing.T) {
+	f := Formatter{MinIntDigits: 5, PadChar: '0'}
+
+	buf, err := f.Append(nil, 42, 0, 10)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+	if string(buf) != "00042" {
+		t.Fatalf(`expected "00042", got %q`, buf)
+	}
+
+	num, err := f.Parse("00042", 0, 10)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+	if num != 42 {
+		t.Fatalf(`expected 42, got %v`, num)
+	}
+
+	// `PadChar` only affects `Append`; a non-digit pad character isn't
+	// itself parseable back by `Parse`, since `ParseWith` has no notion of
+	// padding.
+	f.PadChar = ' '
+	buf, err = f.Append(nil, 42, 0, 10)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v`, err)
+	}
+	if string(buf) != "   42" {
+		t.Fatalf(`expected "   42", got %q`, buf)
+	}
+}
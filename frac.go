@@ -41,9 +41,26 @@ For example, for `frac = 2, radix = 10`, "123.45" is parsed into the number
 12345, while "123.456" is rejected with an error because it exceeds the
 allotted precision.
 
-See `readme.md` for examples.
+Also accepts an optional exponent suffix, "e"/"E" for `radix = 10` and
+"p"/"P" for other radixes (as in Go's hex floats), e.g. "1.23e4" or
+"1.aBp+3". The exponent shifts the fixed-point result by that many powers
+of `radix`.
+
+See `readme.md` for examples. Shortcut for `ParseWith` with `ParseOpts{}`;
+use `ParseWith` to choose a rounding mode instead of rejecting
+over-precision input.
 */
 func Parse(src string, frac uint, radix uint) (num int64, err error) {
+	return ParseWith(src, frac, radix, ParseOpts{})
+}
+
+/*
+Same as `Parse`, but takes `ParseOpts` to control how over-precision input
+(more fractional digits than `frac` allows) is handled. With the zero value
+of `ParseOpts`, this is exactly equivalent to `Parse`: any nonzero digit past
+`frac` is rejected. See `RoundingMode` for the other options.
+*/
+func ParseWith(src string, frac uint, radix uint, opts ParseOpts) (num int64, err error) {
 	if len(src) == 0 {
 		return 0, fmt.Errorf(`can't parse empty input as number`)
 	}
@@ -52,19 +69,35 @@ func Parse(src string, frac uint, radix uint) (num int64, err error) {
 		return 0, fmt.Errorf(`can't parse %q as number: unsupported radix %v`, src, radix)
 	}
 
+	mantissa, expNeg, expVal, _, err := splitExponent(src, radix)
+	if err != nil {
+		return 0, err
+	}
+
 	var sign int64 = 1
-	var expDigs uint
+	var fracDigs uint
+	var excessSeen bool
+	var excessFirst byte
+	var excessRest bool
+
+	// A non-negative exponent extends how many literal fractional digits
+	// count as significant instead of excess, since it shifts them back
+	// into range; see `splitExponent`/`applyExp`.
+	fracCutoff := uint64(frac)
+	if !expNeg {
+		fracCutoff += expVal
+	}
 
 	const (
 		stepSign = iota
 		stepMantStart
 		stepMant
-		stepExpStart
-		stepExp
+		stepFracStart
+		stepFrac
 	)
 	step := stepSign
 
-	for i, char := range []byte(src) {
+	for i, char := range []byte(mantissa) {
 		if step == stepSign {
 			if char == '+' {
 				step = stepMantStart
@@ -81,30 +114,36 @@ func Parse(src string, frac uint, radix uint) (num int64, err error) {
 		}
 
 		if step == stepMant && char == '.' {
-			step = stepExpStart
+			step = stepFracStart
 			continue
 		}
 
 		if step == stepMantStart {
 			step = stepMant
-		} else if step == stepExpStart {
-			step = stepExp
+		} else if step == stepFracStart {
+			step = stepFrac
 		}
 
 		digit := toDigit(char)
 		if digit == unDigit || uint(digit) >= radix {
 			return 0, fmt.Errorf(`can't parse %q as number (radix %v, fraction %v): found non-digit character %q`,
-				src, radix, frac, runeAt(src, i))
+				src, radix, frac, runeAt(mantissa, i))
 		}
 
-		if step == stepExp {
-			expDigs++
-			if expDigs > frac {
-				if digit == 0 {
-					continue
+		if step == stepFrac {
+			fracDigs++
+			if uint64(fracDigs) > fracCutoff {
+				if !excessSeen {
+					excessSeen = true
+					excessFirst = digit
+				} else if digit != 0 {
+					excessRest = true
 				}
-				return 0, fmt.Errorf(`can't parse %q as number (radix %v, fraction %v): exponent exceeds allotted fractional precision`,
-					src, radix, frac)
+				if digit != 0 && opts.Rounding == RoundStrict {
+					return 0, fmt.Errorf(`can't parse %q as number (radix %v, fraction %v): too many fractional digits`,
+						src, radix, frac)
+				}
+				continue
 			}
 		}
 
@@ -119,15 +158,47 @@ func Parse(src string, frac uint, radix uint) (num int64, err error) {
 		}
 	}
 
-	for expDigs < frac {
+	for fracDigs < frac {
 		num, err = inc(src, num, radix, sign, 0)
 		if err != nil {
 			return 0, err
 		}
-		expDigs++
+		fracDigs++
 	}
 
-	if step != stepMant && step != stepExp {
+	if excessSeen {
+		away, err := roundAway(src, opts.Rounding, num, radix, excessFirst, excessRest, sign < 0)
+		if err != nil {
+			return 0, err
+		}
+		if away {
+			num, err = bump(src, num, sign)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// Only the portion of a non-negative exponent not already absorbed by
+	// `fracCutoff` above (because there weren't enough literal digits to
+	// use it all) still needs to be applied as a shift.
+	remainingNeg, remainingVal := expNeg, expVal
+	if !expNeg {
+		var used uint64
+		if uint64(fracDigs) > uint64(frac) {
+			used = uint64(fracDigs) - uint64(frac)
+			if used > expVal {
+				used = expVal
+			}
+		}
+		remainingVal = expVal - used
+	}
+	num, err = applyExp(src, num, radix, sign, opts.Rounding, remainingNeg, remainingVal)
+	if err != nil {
+		return 0, err
+	}
+
+	if step != stepMant && step != stepFrac {
 		return 0, fmt.Errorf(`can't parse %q as number (radix %v, fraction %v): unexpected end of input`,
 			src, radix, frac)
 	}
@@ -286,6 +357,19 @@ func inc(src string, prev int64, radix uint, sign int64, digit byte) (int64, err
 	return next, nil
 }
 
+// Adds one unit in the last place, in the direction of `sign`, used when
+// rounding away from zero. Unlike `inc`, this doesn't shift `prev` by radix.
+func bump(src string, prev int64, sign int64) (int64, error) {
+	next := prev + sign
+	if prev > 0 && next < prev {
+		return 0, fmt.Errorf(`can't parse %q as number: overflow of %T`, src, next)
+	}
+	if prev < 0 && next > prev {
+		return 0, fmt.Errorf(`can't parse %q as number: underflow of %T`, src, next)
+	}
+	return next, nil
+}
+
 const unDigit byte = 255
 
 func toDigit(char byte) byte {
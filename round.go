@@ -0,0 +1,133 @@
+package frac
+
+import "fmt"
+
+/*
+Specifies how `ParseWith` handles input with more fractional digits than
+`frac` allows. The zero value is `RoundStrict`, which preserves the
+behavior of `Parse`: rejects any excess digit that isn't zero.
+*/
+type RoundingMode byte
+
+const (
+	// Rejects input whose fraction exceeds `frac`, unless the excess
+	// digits are all zero. This is the zero value and the behavior of
+	// `Parse`.
+	RoundStrict RoundingMode = iota
+
+	// Discards excess digits, rounding toward zero.
+	RoundDown
+
+	// Rounds away from zero when any excess digit is nonzero.
+	RoundUp
+
+	// Rounds half to even (banker's rounding): a tie breaks toward
+	// whichever neighbor has an even last digit.
+	RoundHalfEven
+
+	// Rounds half away from zero: a tie rounds up in magnitude.
+	RoundHalfUp
+
+	// Rounds half toward zero: a tie rounds down in magnitude.
+	RoundHalfDown
+
+	// Rounds toward positive infinity when any excess digit is nonzero.
+	RoundCeiling
+
+	// Rounds toward negative infinity when any excess digit is nonzero.
+	RoundFloor
+)
+
+// Alias for `RoundDown`, matching the term used by some other decimal
+// libraries.
+const RoundTruncate = RoundDown
+
+// Options for `ParseWith` and `UnmarshalWith`.
+type ParseOpts struct {
+	// Controls how over-precision input is rounded. Defaults to
+	// `RoundStrict`.
+	Rounding RoundingMode
+}
+
+// Same as `Unmarshal` but takes `ParseOpts` to control rounding. See
+// `ParseWith` for details.
+func UnmarshalWith(src []byte, frac uint, radix uint, opts ParseOpts) (int64, error) {
+	return ParseWith(bytesToMutableString(src), frac, radix, opts)
+}
+
+/*
+Decides whether the digits past `frac`, described by the first excess digit
+and whether any later excess digit was nonzero, should round the already
+accumulated `num` away from zero by one unit in the last place. `neg` is
+the sign of the value being parsed, passed in separately rather than
+inferred from `num`, since `num` is the truncated in-precision accumulator
+and is `0` (never "negative") whenever every retained digit is zero, e.g.
+`"-0.001"` at `frac = 2` — `decideRoundAway` needs the real sign for
+`RoundCeiling`/`RoundFloor` to pick the correct direction in that case.
+*/
+func roundAway(src string, mode RoundingMode, num int64, radix uint, first byte, rest bool, neg bool) (bool, error) {
+	away, err := decideRoundAway(mode, radix, first, rest, neg, func() bool {
+		return lastDigitOdd(num, radix)
+	})
+	if err != nil {
+		return false, fmt.Errorf(`can't parse %q as number: %w`, src, err)
+	}
+	return away, nil
+}
+
+/*
+Shared core of `roundAway` and `roundAwayBig`: decides, for any rounding
+mode, whether the digits past the cutoff should round the accumulated
+value away from zero by one unit in the last place. Takes `neg` and
+`lastDigitOdd` (the latter lazy, since it's only needed to break a
+`RoundHalfEven` tie) instead of the accumulator itself, so it works the
+same way whether the accumulator is an `int64` or a `*big.Int`.
+*/
+func decideRoundAway(mode RoundingMode, radix uint, first byte, rest bool, neg bool, lastDigitOdd func() bool) (bool, error) {
+	nonZero := first != 0 || rest
+
+	switch mode {
+	case RoundStrict, RoundDown:
+		return false, nil
+
+	case RoundUp:
+		return nonZero, nil
+
+	case RoundCeiling:
+		return nonZero && !neg, nil
+
+	case RoundFloor:
+		return nonZero && neg, nil
+
+	case RoundHalfUp:
+		return uint(first)*2 >= radix, nil
+
+	case RoundHalfDown:
+		half := uint(first) * 2
+		return half > radix || (half == radix && rest), nil
+
+	case RoundHalfEven:
+		half := uint(first) * 2
+		if half > radix || (half == radix && rest) {
+			return true, nil
+		}
+		if half == radix {
+			return lastDigitOdd(), nil
+		}
+		return false, nil
+
+	default:
+		return false, fmt.Errorf(`unsupported rounding mode %v`, mode)
+	}
+}
+
+// True if the last placed digit of `num`, in the given radix, is odd.
+func lastDigitOdd(num int64, radix uint) bool {
+	var mag uint64
+	if num < 0 {
+		mag = uint64(-num)
+	} else {
+		mag = uint64(num)
+	}
+	return mag%uint64(radix)%2 != 0
+}
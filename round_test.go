@@ -0,0 +1,89 @@
+package frac
+
+import "testing"
+
+// Covers each `RoundingMode` against both positive and negative input,
+// including the near-zero-magnitude case that `roundAway` got wrong when it
+// inferred sign from the truncated accumulator instead of the parsed sign:
+// "-0.001" at frac=2 truncates to num=0, which isn't itself negative, so
+// `RoundCeiling`/`RoundFloor` need the sign threaded in separately.
+func TestParseWithRounding(t *testing.T) {
+	type test struct {
+		src  string
+		mode RoundingMode
+		want int64
+	}
+
+	tests := []test{
+		{"1.004", RoundDown, 100},
+		{"-1.004", RoundDown, -100},
+		{"1.006", RoundDown, 100},
+		{"-1.006", RoundDown, -100},
+
+		{"1.004", RoundUp, 101},
+		{"-1.004", RoundUp, -101},
+		{"1.000", RoundUp, 100},
+		{"-1.000", RoundUp, -100},
+
+		{"1.005", RoundHalfEven, 100},
+		{"1.015", RoundHalfEven, 102},
+		{"-1.005", RoundHalfEven, -100},
+		{"-1.015", RoundHalfEven, -102},
+		{"1.004", RoundHalfEven, 100},
+		{"1.006", RoundHalfEven, 101},
+
+		{"1.005", RoundHalfUp, 101},
+		{"-1.005", RoundHalfUp, -101},
+		{"1.004", RoundHalfUp, 100},
+		{"1.006", RoundHalfUp, 101},
+
+		{"1.005", RoundHalfDown, 100},
+		{"-1.005", RoundHalfDown, -100},
+		{"1.004", RoundHalfDown, 100},
+		{"1.006", RoundHalfDown, 101},
+
+		// Ordinary, non-zero-magnitude cases.
+		{"1.006", RoundCeiling, 101},
+		{"-1.006", RoundCeiling, -100},
+		{"1.004", RoundCeiling, 101},
+		{"-1.004", RoundCeiling, -100},
+
+		{"1.006", RoundFloor, 100},
+		{"-1.006", RoundFloor, -101},
+		{"1.004", RoundFloor, 100},
+		{"-1.004", RoundFloor, -101},
+
+		// Near-zero-magnitude: every retained digit is 0, so the truncated
+		// accumulator is 0 and can't be used as a proxy for the sign.
+		{"0.001", RoundCeiling, 1},
+		{"-0.001", RoundCeiling, 0},
+		{"0.001", RoundFloor, 0},
+		{"-0.001", RoundFloor, -1},
+
+		// Same near-zero-magnitude case, but shed via a negative exponent
+		// instead of literal over-precision fraction digits.
+		{"5e-3", RoundCeiling, 1},
+		{"-5e-3", RoundCeiling, 0},
+		{"5e-3", RoundFloor, 0},
+		{"-5e-3", RoundFloor, -1},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseWith(tc.src, 2, 10, ParseOpts{Rounding: tc.mode})
+		if err != nil {
+			t.Fatalf(`mode %v, %q: unexpected error: %v`, tc.mode, tc.src, err)
+		}
+		if got != tc.want {
+			t.Fatalf(`mode %v, %q: expected %v, got %v`, tc.mode, tc.src, tc.want, got)
+		}
+	}
+}
+
+func TestParseWithRoundStrict(t *testing.T) {
+	if _, err := ParseWith("1.005", 2, 10, ParseOpts{}); err == nil {
+		t.Fatalf(`expected an error for over-precision input under RoundStrict`)
+	}
+	if got, err := ParseWith("1.00", 2, 10, ParseOpts{}); err != nil || got != 100 {
+		t.Fatalf(`expected 100, nil, got %v, %v`, got, err)
+	}
+}
@@ -0,0 +1,111 @@
+package frac
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Default delimiter bytes for `Decoder`, covering comma- and tab-separated
+// input plus line endings.
+const defaultDelims = ",\t\n\r"
+
+/*
+Reads fractional numbers one at a time out of a delimited stream, such as a
+CSV or TSV file, without allocating a string per field. Buffers each field
+into a reused byte slice, then hands it to `ParseWith`, so it supports the
+same exponent notation and rounding modes as `Parse`.
+*/
+type Decoder struct {
+	r     *bufio.Reader
+	frac  uint
+	radix uint
+	buf   []byte
+
+	// Bytes that separate one number from the next. Defaults to
+	// `,\t\n\r`; assign before the first `ReadNumber` call to customize.
+	Delims string
+
+	// Controls how over-precision input is rounded, same as
+	// `ParseOpts.Rounding`. Defaults to `RoundStrict`.
+	Rounding RoundingMode
+}
+
+// Constructs a `Decoder` reading from `r`, with the given fixed-point
+// precision and radix. See `Decoder.Delims` to customize field separators.
+func NewDecoder(r io.Reader, frac uint, radix uint) *Decoder {
+	return &Decoder{r: bufio.NewReader(r), frac: frac, radix: radix, Delims: defaultDelims}
+}
+
+func (d *Decoder) isDelim(char byte) bool {
+	return strings.IndexByte(d.Delims, char) >= 0
+}
+
+/*
+Reads and parses the next fractional number, skipping any leading delimiter
+bytes. Returns `io.EOF` once the stream is exhausted. Delegates to
+`ParseWith` with `d.Rounding`, so it accepts the same input `Parse` does,
+including an exponent suffix.
+*/
+func (d *Decoder) ReadNumber() (int64, error) {
+	for {
+		char, err := d.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if d.isDelim(char) {
+			continue
+		}
+		if err := d.r.UnreadByte(); err != nil {
+			return 0, err
+		}
+		break
+	}
+
+	d.buf = d.buf[:0]
+	for {
+		char, err := d.r.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if d.isDelim(char) {
+			break
+		}
+		d.buf = append(d.buf, char)
+	}
+
+	return ParseWith(bytesToMutableString(d.buf), d.frac, d.radix, ParseOpts{Rounding: d.Rounding})
+}
+
+/*
+Writes fractional numbers to a stream one at a time, via `Append`, reusing
+an internal buffer instead of allocating a string per call. Does not write
+delimiters between numbers; the caller is responsible for those.
+*/
+type Encoder struct {
+	w     io.Writer
+	frac  uint
+	radix uint
+	buf   []byte
+}
+
+// Constructs an `Encoder` writing to `w`, with the given fixed-point
+// precision and radix.
+func NewEncoder(w io.Writer, frac uint, radix uint) *Encoder {
+	return &Encoder{w: w, frac: frac, radix: radix}
+}
+
+// Formats `num` and writes it to the underlying writer.
+func (e *Encoder) WriteNumber(num int64) error {
+	buf, err := Append(e.buf[:0], num, e.frac, e.radix)
+	if err != nil {
+		return err
+	}
+	e.buf = buf
+
+	_, err = e.w.Write(buf)
+	return err
+}
@@ -0,0 +1,41 @@
+package frac
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecoderReadNumber(t *testing.T) {
+	t.Run(`basic fields and EOF`, func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader("1.5,2.25\n-3"), 2, 10)
+
+		for _, want := range []int64{150, 225, -300} {
+			got, err := dec.ReadNumber()
+			if err != nil {
+				t.Fatalf(`unexpected error: %v`, err)
+			}
+			if got != want {
+				t.Fatalf(`expected %v, got %v`, want, got)
+			}
+		}
+
+		if _, err := dec.ReadNumber(); err == nil {
+			t.Fatalf(`expected an error at EOF`)
+		}
+	})
+
+	t.Run(`exponent and rounding mode, same as ParseWith`, func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader("1.2345e2,1.005"), 2, 10)
+		dec.Rounding = RoundHalfUp
+
+		for _, want := range []int64{12345, 101} {
+			got, err := dec.ReadNumber()
+			if err != nil {
+				t.Fatalf(`unexpected error: %v`, err)
+			}
+			if got != want {
+				t.Fatalf(`expected %v, got %v`, want, got)
+			}
+		}
+	})
+}